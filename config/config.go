@@ -0,0 +1,146 @@
+// Package config parses upfeed's YAML configuration file: the set of
+// feeds to poll and the filter rules applied to their jobs.
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"time"
+
+	"github.com/zakkor/upfeed/filter"
+	"github.com/zakkor/upfeed/notify"
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the top-level structure of the upfeed.yaml configuration file.
+type Config struct {
+	Feeds   []FeedConfig       `yaml:"feeds"`
+	Filters []FilterRuleConfig `yaml:"filters"`
+	Sinks   []SinkConfig       `yaml:"sinks"`
+}
+
+// FeedConfig describes a single feed to poll. Filters and Sinks are
+// additional rules/sinks applied only to this feed, on top of the
+// global ones.
+type FeedConfig struct {
+	ID           string             `yaml:"id"`
+	URL          string             `yaml:"url"`
+	PollInterval time.Duration      `yaml:"poll_interval"`
+	Filters      []FilterRuleConfig `yaml:"filters"`
+	Sinks        []SinkConfig       `yaml:"sinks"`
+}
+
+// FilterRuleConfig is the on-disk representation of a single filter rule.
+// Each rule should set the fields for exactly one predicate below; the
+// first non-empty predicate wins when the rule is built.
+type FilterRuleConfig struct {
+	Name string `yaml:"name"`
+
+	CountryAllow []string `yaml:"country_allow"`
+	CountryBlock []string `yaml:"country_block"`
+
+	MinHourlyRate  *float32 `yaml:"min_hourly_rate"`
+	MinFixedBudget *int     `yaml:"min_fixed_budget"`
+
+	RequiredSkills  []string `yaml:"required_skills"`
+	ForbiddenSkills []string `yaml:"forbidden_skills"`
+	SkillsAreRegex  bool     `yaml:"skills_are_regex"`
+
+	TitleExclude string `yaml:"title_exclude"`
+
+	CategoryAllow []string `yaml:"category_allow"`
+	CategoryBlock []string `yaml:"category_block"`
+}
+
+// Load reads and parses the config file at path.
+func Load(path string) (Config, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("read config: %w", err)
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return Config{}, fmt.Errorf("parse config: %w", err)
+	}
+
+	for i := range cfg.Feeds {
+		if cfg.Feeds[i].PollInterval == 0 {
+			cfg.Feeds[i].PollInterval = 30 * time.Second
+		}
+	}
+
+	return cfg, nil
+}
+
+// BuildFilterSet turns the declarative rule configs into a filter.Set of
+// concrete Rule implementations.
+func BuildFilterSet(rules []FilterRuleConfig) (filter.Set, error) {
+	var fs filter.Set
+	for _, rc := range rules {
+		rule, err := rc.buildRule()
+		if err != nil {
+			return filter.Set{}, fmt.Errorf("rule %q: %w", rc.Name, err)
+		}
+		fs.Rules = append(fs.Rules, rule)
+	}
+	return fs, nil
+}
+
+// FeedFilterSet returns the combined global + per-feed filter.Set for fc.
+func (c Config) FeedFilterSet(fc FeedConfig) (filter.Set, error) {
+	fs, err := BuildFilterSet(c.Filters)
+	if err != nil {
+		return filter.Set{}, err
+	}
+	feedFs, err := BuildFilterSet(fc.Filters)
+	if err != nil {
+		return filter.Set{}, err
+	}
+	fs.Rules = append(fs.Rules, feedFs.Rules...)
+	return fs, nil
+}
+
+// FeedSinks returns the combined global + per-feed notify.Multi for fc.
+// If neither global nor per-feed sinks are configured, it falls back to
+// a single DesktopSink, matching upfeed's original behavior.
+func (c Config) FeedSinks(fc FeedConfig) (notify.Multi, error) {
+	sinks, err := BuildSinks(c.Sinks)
+	if err != nil {
+		return nil, err
+	}
+	feedSinks, err := BuildSinks(fc.Sinks)
+	if err != nil {
+		return nil, err
+	}
+	sinks = append(sinks, feedSinks...)
+
+	if len(sinks) == 0 {
+		sinks = notify.Multi{notify.DesktopSink{}}
+	}
+	return sinks, nil
+}
+
+func (rc FilterRuleConfig) buildRule() (filter.Rule, error) {
+	switch {
+	case len(rc.CountryAllow) > 0 || len(rc.CountryBlock) > 0:
+		return &filter.CountryRule{RuleName: rc.Name, Allow: rc.CountryAllow, Block: rc.CountryBlock}, nil
+	case rc.MinHourlyRate != nil:
+		return &filter.MinHourlyRateRule{RuleName: rc.Name, Min: *rc.MinHourlyRate}, nil
+	case rc.MinFixedBudget != nil:
+		return &filter.MinBudgetRule{RuleName: rc.Name, Min: *rc.MinFixedBudget}, nil
+	case len(rc.RequiredSkills) > 0 || len(rc.ForbiddenSkills) > 0:
+		return filter.NewSkillRule(rc.Name, rc.RequiredSkills, rc.ForbiddenSkills, rc.SkillsAreRegex)
+	case rc.TitleExclude != "":
+		re, err := regexp.Compile(rc.TitleExclude)
+		if err != nil {
+			return nil, err
+		}
+		return &filter.TitleExcludeRule{RuleName: rc.Name, Re: re}, nil
+	case len(rc.CategoryAllow) > 0 || len(rc.CategoryBlock) > 0:
+		return &filter.CategoryRule{RuleName: rc.Name, Allow: rc.CategoryAllow, Block: rc.CategoryBlock}, nil
+	default:
+		return nil, fmt.Errorf("rule %q has no recognized predicate", rc.Name)
+	}
+}
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/zakkor/upfeed/notify"
+)
+
+// SinkConfig is the on-disk representation of a single notification
+// sink. Each entry should set the fields for exactly one sink below;
+// the first non-empty one wins when the sink is built.
+type SinkConfig struct {
+	Desktop bool `yaml:"desktop"`
+
+	DiscordWebhook string `yaml:"discord_webhook"`
+	SlackWebhook   string `yaml:"slack_webhook"`
+	Webhook        string `yaml:"webhook"`
+
+	NtfyTopic  string `yaml:"ntfy_topic"`
+	NtfyServer string `yaml:"ntfy_server"`
+
+	SMTP *SMTPSinkConfig `yaml:"smtp"`
+
+	Noop bool `yaml:"noop"`
+}
+
+// SMTPSinkConfig configures the SMTP sink.
+type SMTPSinkConfig struct {
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	From     string `yaml:"from"`
+	To       string `yaml:"to"`
+}
+
+// BuildSinks turns the declarative sink configs into concrete
+// notify.Sink implementations.
+func BuildSinks(sinks []SinkConfig) (notify.Multi, error) {
+	var out notify.Multi
+	for _, sc := range sinks {
+		sink, err := sc.buildSink()
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, sink)
+	}
+	return out, nil
+}
+
+func (sc SinkConfig) buildSink() (notify.Sink, error) {
+	switch {
+	case sc.Desktop:
+		return notify.DesktopSink{}, nil
+	case sc.DiscordWebhook != "":
+		return notify.DiscordSink{URL: sc.DiscordWebhook}, nil
+	case sc.SlackWebhook != "":
+		return notify.SlackSink{URL: sc.SlackWebhook}, nil
+	case sc.Webhook != "":
+		return notify.WebhookSink{URL: sc.Webhook}, nil
+	case sc.NtfyTopic != "":
+		return notify.NtfySink{ServerURL: sc.NtfyServer, Topic: sc.NtfyTopic}, nil
+	case sc.SMTP != nil:
+		return notify.SMTPSink{
+			Host:     sc.SMTP.Host,
+			Port:     sc.SMTP.Port,
+			Username: sc.SMTP.Username,
+			Password: sc.SMTP.Password,
+			From:     sc.SMTP.From,
+			To:       sc.SMTP.To,
+		}, nil
+	case sc.Noop:
+		return notify.NoopSink{}, nil
+	default:
+		return nil, fmt.Errorf("sink has no recognized backend")
+	}
+}
@@ -0,0 +1,129 @@
+// Package feeds polls one or more job feeds concurrently and persists
+// their jobs to disk.
+package feeds
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/zakkor/upfeed/config"
+	"github.com/zakkor/upfeed/filter"
+	"github.com/zakkor/upfeed/job"
+	"github.com/zakkor/upfeed/notify"
+	"github.com/zakkor/upfeed/storage"
+)
+
+// Manager owns the set of feed workers and the persisted job store.
+type Manager struct {
+	Store storage.Store
+
+	parsers sync.Pool
+}
+
+// NewManager creates a Manager that persists job state to store.
+func NewManager(store storage.Store) *Manager {
+	return &Manager{
+		Store:   store,
+		parsers: sync.Pool{New: func() interface{} { return gofeed.NewParser() }},
+	}
+}
+
+// Run starts one worker goroutine per feed and blocks until ctx is
+// cancelled, at which point it waits for all workers to exit.
+func (m *Manager) Run(ctx context.Context, cfg config.Config) error {
+	var wg sync.WaitGroup
+	for _, fc := range cfg.Feeds {
+		fs, err := cfg.FeedFilterSet(fc)
+		if err != nil {
+			return err
+		}
+		sinks, err := cfg.FeedSinks(fc)
+		if err != nil {
+			return err
+		}
+
+		wg.Add(1)
+		go func(fc config.FeedConfig, fs filter.Set, sinks notify.Multi) {
+			defer wg.Done()
+			m.pollFeed(ctx, fc, fs, sinks)
+		}(fc, fs, sinks)
+	}
+
+	wg.Wait()
+	return nil
+}
+
+func (m *Manager) pollFeed(ctx context.Context, fc config.FeedConfig, fs filter.Set, sinks notify.Multi) {
+	ticker := time.NewTicker(fc.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		m.poll(fc, fs, sinks)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *Manager) poll(fc config.FeedConfig, fs filter.Set, sinks notify.Multi) {
+	parser := m.parsers.Get().(*gofeed.Parser)
+	defer m.parsers.Put(parser)
+
+	feed, err := parser.ParseURL(fc.URL)
+	if err != nil {
+		log.Printf("feed %s: %v", fc.ID, err)
+		return
+	}
+
+	for _, item := range feed.Items {
+		has, err := m.Store.Has(fc.ID, item.GUID)
+		if err != nil {
+			log.Printf("feed %s: %v", fc.ID, err)
+			continue
+		}
+		if has {
+			continue
+		}
+
+		hasQuarantined, err := m.Store.HasQuarantined(fc.ID, item.GUID)
+		if err != nil {
+			log.Printf("feed %s: %v", fc.ID, err)
+			continue
+		}
+		if hasQuarantined {
+			continue
+		}
+
+		j, err := job.ParseJob(item)
+		if err != nil {
+			if qerr := m.Store.Quarantine(fc.ID, item.GUID, job.RawContent(item), err); qerr != nil {
+				log.Printf("feed %s: quarantine: %v", fc.ID, qerr)
+			}
+			continue
+		}
+
+		junk, reason := fs.Junk(j)
+		if err := m.Store.Save(fc.ID, j, !junk, reason); err != nil {
+			log.Printf("feed %s: save: %v", fc.ID, err)
+			continue
+		}
+
+		// A single failing sink must not stop the poller; the job is
+		// already saved above, so log and move on to the next item.
+		var n notify.Notification
+		if !junk {
+			n = notify.Notification{Title: j.Title, Body: j.Format(), Icon: "assets/information.png", Job: &j}
+		} else {
+			n = notify.Notification{Title: "Job filtered out", Body: reason, Icon: "assets/information.png"}
+		}
+		if err := sinks.Send(n); err != nil {
+			log.Printf("feed %s: notify: %v", fc.ID, err)
+		}
+	}
+}
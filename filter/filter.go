@@ -0,0 +1,173 @@
+// Package filter implements the pluggable job-filtering rule engine.
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/zakkor/upfeed/job"
+)
+
+// Rule is a single named predicate that can mark a Job as junk.
+type Rule interface {
+	// Match reports whether j should be filtered out by this rule.
+	Match(j job.Job) bool
+	// Name identifies the rule, surfaced in notifications so the user
+	// knows which rule fired.
+	Name() string
+}
+
+// Set is an ordered collection of Rules, built from the config file.
+// The first rule that matches a job decides the outcome.
+type Set struct {
+	Rules []Rule
+}
+
+// Junk reports whether j should be filtered out, and if so, which rule
+// matched.
+func (s Set) Junk(j job.Job) (bool, string) {
+	for _, rule := range s.Rules {
+		if rule.Match(j) {
+			return true, fmt.Sprintf("filtered by rule: %s", rule.Name())
+		}
+	}
+	return false, ""
+}
+
+type CountryRule struct {
+	RuleName string
+	Allow    []string
+	Block    []string
+}
+
+func (r *CountryRule) Name() string { return r.RuleName }
+
+func (r *CountryRule) Match(j job.Job) bool {
+	if len(r.Allow) > 0 && !containsFold(r.Allow, j.Country) {
+		return true
+	}
+	return containsFold(r.Block, j.Country)
+}
+
+type MinHourlyRateRule struct {
+	RuleName string
+	Min      float32
+}
+
+func (r *MinHourlyRateRule) Name() string { return r.RuleName }
+
+func (r *MinHourlyRateRule) Match(j job.Job) bool {
+	if !j.IsHourly {
+		return false
+	}
+	return j.HourlyRange[1] < r.Min
+}
+
+type MinBudgetRule struct {
+	RuleName string
+	Min      int
+}
+
+func (r *MinBudgetRule) Name() string { return r.RuleName }
+
+func (r *MinBudgetRule) Match(j job.Job) bool {
+	if j.IsHourly {
+		return false
+	}
+	return j.Budget < r.Min
+}
+
+// SkillRule filters on required/forbidden skills, each either a plain
+// substring or a regex depending on NewSkillRule's areRegex argument.
+type SkillRule struct {
+	RuleName  string
+	Required  []*regexp.Regexp
+	Forbidden []*regexp.Regexp
+}
+
+// NewSkillRule compiles required/forbidden skill patterns into a SkillRule.
+// If areRegex is false, each pattern is treated as a literal substring.
+func NewSkillRule(name string, required, forbidden []string, areRegex bool) (*SkillRule, error) {
+	r := &SkillRule{RuleName: name}
+	for _, s := range required {
+		re, err := compileSkillPattern(s, areRegex)
+		if err != nil {
+			return nil, err
+		}
+		r.Required = append(r.Required, re)
+	}
+	for _, s := range forbidden {
+		re, err := compileSkillPattern(s, areRegex)
+		if err != nil {
+			return nil, err
+		}
+		r.Forbidden = append(r.Forbidden, re)
+	}
+	return r, nil
+}
+
+func compileSkillPattern(s string, isRegex bool) (*regexp.Regexp, error) {
+	if !isRegex {
+		s = regexp.QuoteMeta(s)
+	}
+	return regexp.Compile("(?i)" + s)
+}
+
+func (r *SkillRule) Name() string { return r.RuleName }
+
+func (r *SkillRule) Match(j job.Job) bool {
+	for _, re := range r.Forbidden {
+		if matchesAnySkill(re, j.Skills) {
+			return true
+		}
+	}
+	for _, re := range r.Required {
+		if !matchesAnySkill(re, j.Skills) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesAnySkill(re *regexp.Regexp, skills []string) bool {
+	for _, s := range skills {
+		if re.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
+
+type TitleExcludeRule struct {
+	RuleName string
+	Re       *regexp.Regexp
+}
+
+func (r *TitleExcludeRule) Name() string { return r.RuleName }
+
+func (r *TitleExcludeRule) Match(j job.Job) bool { return r.Re.MatchString(j.Title) }
+
+type CategoryRule struct {
+	RuleName string
+	Allow    []string
+	Block    []string
+}
+
+func (r *CategoryRule) Name() string { return r.RuleName }
+
+func (r *CategoryRule) Match(j job.Job) bool {
+	if len(r.Allow) > 0 && !containsFold(r.Allow, j.Category) {
+		return true
+	}
+	return containsFold(r.Block, j.Category)
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
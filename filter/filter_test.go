@@ -0,0 +1,118 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/zakkor/upfeed/job"
+)
+
+func TestCountryRuleMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    CountryRule
+		country string
+		want    bool
+	}{
+		{name: "blocked", rule: CountryRule{Block: []string{"India"}}, country: "India", want: true},
+		{name: "blocked case-insensitive", rule: CountryRule{Block: []string{"india"}}, country: "India", want: true},
+		{name: "not blocked", rule: CountryRule{Block: []string{"India"}}, country: "Canada", want: false},
+		{name: "not in allow list", rule: CountryRule{Allow: []string{"United States"}}, country: "Canada", want: true},
+		{name: "in allow list", rule: CountryRule{Allow: []string{"United States"}}, country: "United States", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.Match(job.Job{Country: tt.country}); got != tt.want {
+				t.Errorf("Match(country=%q) = %v; want %v", tt.country, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMinHourlyRateRuleMatch(t *testing.T) {
+	rule := MinHourlyRateRule{Min: 20}
+
+	tests := []struct {
+		name string
+		j    job.Job
+		want bool
+	}{
+		{name: "below minimum", j: job.Job{IsHourly: true, HourlyRange: [2]float32{10, 15}}, want: true},
+		{name: "at minimum", j: job.Job{IsHourly: true, HourlyRange: [2]float32{10, 20}}, want: false},
+		{name: "above minimum", j: job.Job{IsHourly: true, HourlyRange: [2]float32{25, 30}}, want: false},
+		{name: "fixed price job is never matched", j: job.Job{IsHourly: false, Budget: 5}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rule.Match(tt.j); got != tt.want {
+				t.Errorf("Match(%+v) = %v; want %v", tt.j, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMinBudgetRuleMatch(t *testing.T) {
+	rule := MinBudgetRule{Min: 500}
+
+	tests := []struct {
+		name string
+		j    job.Job
+		want bool
+	}{
+		{name: "below minimum", j: job.Job{IsHourly: false, Budget: 100}, want: true},
+		{name: "at minimum", j: job.Job{IsHourly: false, Budget: 500}, want: false},
+		{name: "above minimum", j: job.Job{IsHourly: false, Budget: 1000}, want: false},
+		{name: "hourly job is never matched", j: job.Job{IsHourly: true, HourlyRange: [2]float32{1, 1}}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rule.Match(tt.j); got != tt.want {
+				t.Errorf("Match(%+v) = %v; want %v", tt.j, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSkillRuleMatch(t *testing.T) {
+	rule, err := NewSkillRule("needs-golang", []string{"golang"}, []string{"wordpress"}, false)
+	if err != nil {
+		t.Fatalf("NewSkillRule: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		skills []string
+		want   bool
+	}{
+		{name: "has required, no forbidden", skills: []string{"Golang", "Docker"}, want: false},
+		{name: "missing required", skills: []string{"Docker"}, want: true},
+		{name: "has forbidden", skills: []string{"Golang", "WordPress"}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rule.Match(job.Job{Skills: tt.skills}); got != tt.want {
+				t.Errorf("Match(skills=%v) = %v; want %v", tt.skills, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetJunk(t *testing.T) {
+	set := Set{Rules: []Rule{
+		&CountryRule{RuleName: "country-block", Block: []string{"India"}},
+		&MinBudgetRule{RuleName: "low-budget", Min: 500},
+	}}
+
+	junk, reason := set.Junk(job.Job{Country: "India", Budget: 1000})
+	if !junk || reason == "" {
+		t.Errorf("Junk() = (%v, %q); want filtered by country-block", junk, reason)
+	}
+
+	junk, reason = set.Junk(job.Job{Country: "Canada", Budget: 1000})
+	if junk {
+		t.Errorf("Junk() = (%v, %q); want not junk", junk, reason)
+	}
+}
@@ -0,0 +1,198 @@
+// Package job defines the Job model shared across the feed poller, the
+// filter rules, and the persisted storage layer.
+package job
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+)
+
+type Job struct {
+	GUID        string    `json:"guid"`
+	URL         string    `json:"url"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	PostedOn    time.Time `json:"posted_on"`
+	Category    string    `json:"category"`
+	Skills      []string  `json:"skills"`
+	Country     string    `json:"country"`
+
+	// If false, job is fixed price.
+	IsHourly    bool       `json:"is_hourly"`
+	HourlyRange [2]float32 `json:"hourly_range"`
+	// Only if IsHourly == false
+	Budget int `json:"budget"`
+
+	Client ClientInfo `json:"client"`
+}
+
+// ClientInfo is the client history section that follows the key/value
+// table in the feed item's HTML body.
+type ClientInfo struct {
+	PaymentVerified bool   `json:"payment_verified"`
+	Spent           string `json:"spent"`
+	HireRate        string `json:"hire_rate"`
+	Location        string `json:"location"`
+}
+
+var keyValueRe = regexp.MustCompile(`<b>([a-zA-Z ]+)<\/b>:(.[^<]+)<`)
+
+// RawContent returns the item's full HTML body, preferring the
+// content:encoded extension (where Upwork puts the complete description
+// and client history) over the summary gofeed exposes as Content. It is
+// exported so callers can quarantine the same raw text ParseJob parsed
+// from, rather than item.Content, which can be a truncated summary.
+func RawContent(item *gofeed.Item) string {
+	if ext, ok := item.Extensions["content"]; ok {
+		if encoded, ok := ext["encoded"]; ok && len(encoded) > 0 {
+			return encoded[0].Value
+		}
+	}
+	return item.Content
+}
+
+// ParseJob extracts a Job from a feed item. It returns a *ParseError if
+// any field is malformed, so the caller can quarantine the raw item
+// instead of losing the whole poller to one bad feed entry.
+func ParseJob(item *gofeed.Item) (Job, error) {
+	content := RawContent(item)
+
+	var job Job
+	// Hourly by default
+	job.IsHourly = true
+
+	job.GUID = item.GUID
+	job.URL = item.Link
+	job.Title = strings.TrimRight(item.Title, " - Upwork")
+
+	matches := keyValueRe.FindAllStringSubmatch(content, -1)
+	for _, match := range matches {
+		key := strings.TrimSpace(match[1])
+		val := strings.TrimSpace(match[2])
+
+		switch key {
+		case "Posted On":
+			t, err := parsePostedOn(val)
+			if err != nil {
+				return Job{}, &ParseError{Field: key, Value: val, Err: err}
+			}
+			job.PostedOn = t
+		case "Category":
+			job.Category = val
+		case "Skills":
+			skills := strings.Split(val, ", ")
+			for i := range skills {
+				skills[i] = strings.TrimSpace(skills[i])
+			}
+			job.Skills = skills
+		case "Country":
+			job.Country = val
+		case "Budget":
+			budget, err := parseBudget(val)
+			if err != nil {
+				return Job{}, &ParseError{Field: key, Value: val, Err: err}
+			}
+			job.Budget = budget
+			job.IsHourly = false
+		case "Hourly Range":
+			hourlyRange, err := parseHourlyRange(val)
+			if err != nil {
+				return Job{}, &ParseError{Field: key, Value: val, Err: err}
+			}
+			job.HourlyRange = hourlyRange
+		}
+	}
+
+	job.Description = parseDescription(content)
+	job.Client = parseClientInfo(content)
+
+	return job, nil
+}
+
+var (
+	htmlTagRe    = regexp.MustCompile(`<[^>]*>`)
+	paymentRe    = regexp.MustCompile(`Payment method verified`)
+	spentRe      = regexp.MustCompile(`\$[\d,.]+[KkMm]?\+? spent`)
+	hireRateRe   = regexp.MustCompile(`\d+% hire rate`)
+	clientFromRe = regexp.MustCompile(`(?i)client'?s? location:\s*([^<\n]+)`)
+)
+
+// parseDescription strips the key/value table, cuts off the client
+// history sentences parseClientInfo pulls out separately (otherwise
+// they'd appear twice: once structured, once as free text), and strips
+// any remaining HTML tags, leaving the free-form job description text.
+func parseDescription(content string) string {
+	description := keyValueRe.ReplaceAllString(content, "")
+	if idx := firstClientInfoIndex(description); idx >= 0 {
+		description = description[:idx]
+	}
+	description = htmlTagRe.ReplaceAllString(description, " ")
+	return strings.TrimSpace(collapseSpaces(description))
+}
+
+// firstClientInfoIndex returns the earliest byte offset in s matched by
+// any of the client-info markers parseClientInfo looks for, or -1 if
+// none are present.
+func firstClientInfoIndex(s string) int {
+	idx := -1
+	for _, re := range []*regexp.Regexp{paymentRe, spentRe, hireRateRe, clientFromRe} {
+		if loc := re.FindStringIndex(s); loc != nil && (idx == -1 || loc[0] < idx) {
+			idx = loc[0]
+		}
+	}
+	return idx
+}
+
+// parseClientInfo extracts the client's payment/spend/hire-rate history
+// that Upwork appends after the key/value table.
+func parseClientInfo(content string) ClientInfo {
+	var info ClientInfo
+	info.PaymentVerified = paymentRe.MatchString(content)
+	info.Spent = spentRe.FindString(content)
+	info.HireRate = hireRateRe.FindString(content)
+	if m := clientFromRe.FindStringSubmatch(content); len(m) > 1 {
+		info.Location = strings.TrimSpace(m[1])
+	}
+	return info
+}
+
+func collapseSpaces(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func (j *Job) Format() string {
+	format := fmt.Sprintf("Country: %s\n", j.Country)
+
+	if j.IsHourly {
+		format += fmt.Sprintf("Type: Hourly\nHourly Range: $%v-$%v\n", j.HourlyRange[0], j.HourlyRange[1])
+	} else {
+		format += fmt.Sprintf("Type: Fixed price\nBudget: $%v\n", j.Budget)
+	}
+
+	if j.Client.PaymentVerified {
+		format += "Client: payment verified"
+		if j.Client.Spent != "" {
+			format += fmt.Sprintf(", %s", j.Client.Spent)
+		}
+		if j.Client.HireRate != "" {
+			format += fmt.Sprintf(", %s", j.Client.HireRate)
+		}
+		format += "\n"
+	}
+	if j.Client.Location != "" {
+		format += fmt.Sprintf("Client location: %s\n", j.Client.Location)
+	}
+
+	if j.Description != "" {
+		format += fmt.Sprintf("\n%s\n", j.Description)
+	}
+	if j.URL != "" {
+		format += fmt.Sprintf("\n%s\n", j.URL)
+	}
+
+	return format
+}
@@ -0,0 +1,92 @@
+package job
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseError wraps a single field that couldn't be parsed out of a feed
+// item, so callers can quarantine the raw item instead of crashing.
+type ParseError struct {
+	Field string
+	Value string
+	Err   error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("parse %s %q: %v", e.Field, e.Value, e.Err)
+}
+
+func (e *ParseError) Unwrap() error { return e.Err }
+
+// dateLayouts are tried in order against the "Posted On" field, since
+// Upwork has been observed to vary its date format over time.
+var dateLayouts = []string{
+	"January 2, 2006 15:04 MST",
+	"Jan 2, 2006 15:04 MST",
+	"2006-01-02 15:04:05 MST",
+	time.RFC1123,
+}
+
+func parsePostedOn(val string) (time.Time, error) {
+	var lastErr error
+	for _, layout := range dateLayouts {
+		t, err := time.Parse(layout, val)
+		if err == nil {
+			return t, nil
+		}
+		lastErr = err
+	}
+	return time.Time{}, lastErr
+}
+
+// currencySymbolRe strips any of the currencies upfeed is known to have
+// seen in budget/rate fields, as a prefix or suffix.
+var currencySymbolRe = regexp.MustCompile(`[$€£]`)
+
+func parseBudget(val string) (int, error) {
+	cleaned := currencySymbolRe.ReplaceAllString(val, "")
+	cleaned = strings.ReplaceAll(cleaned, ",", "")
+	cleaned = strings.TrimSpace(strings.TrimSuffix(cleaned, "+"))
+
+	budget, err := strconv.ParseFloat(cleaned, 64)
+	if err != nil {
+		return 0, err
+	}
+	return int(budget), nil
+}
+
+// parseHourlyRange accepts decimals on either bound and an open-ended
+// upper bound written as "$30+" (no second number), in which case the
+// range collapses to [30, 30].
+func parseHourlyRange(val string) ([2]float32, error) {
+	cleaned := currencySymbolRe.ReplaceAllString(val, "")
+	cleaned = strings.TrimSpace(cleaned)
+
+	var hourlyRange [2]float32
+
+	split := strings.SplitN(cleaned, "-", 2)
+	lowerStr := strings.TrimSuffix(strings.TrimSpace(split[0]), "+")
+	lower, err := strconv.ParseFloat(lowerStr, 32)
+	if err != nil {
+		return hourlyRange, err
+	}
+	hourlyRange[0] = float32(lower)
+	hourlyRange[1] = float32(lower)
+
+	if len(split) > 1 {
+		upperStr := strings.TrimSuffix(strings.TrimSpace(split[1]), "+")
+		if upperStr != "" {
+			upper, err := strconv.ParseFloat(upperStr, 32)
+			if err != nil {
+				return hourlyRange, err
+			}
+			hourlyRange[1] = float32(upper)
+		}
+	}
+
+	return hourlyRange, nil
+}
@@ -0,0 +1,122 @@
+package job
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseBudget(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    int
+		wantErr bool
+	}{
+		{name: "dollar prefix", in: "$500", want: 500},
+		{name: "euro prefix", in: "€1,200", want: 1200},
+		{name: "pound prefix", in: "£75", want: 75},
+		{name: "open-ended suffix", in: "$1,000+", want: 1000},
+		{name: "decimal", in: "$99.50", want: 99},
+		{name: "malformed", in: "not a budget", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseBudget(tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseBudget(%q) = %d, nil; want error", tt.in, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseBudget(%q) unexpected error: %v", tt.in, err)
+			}
+			if got != tt.want {
+				t.Errorf("parseBudget(%q) = %d; want %d", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseHourlyRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    [2]float32
+		wantErr bool
+	}{
+		{name: "simple range", in: "$15.00-$25.00", want: [2]float32{15, 25}},
+		{name: "decimal bounds", in: "$15.50-$28.75", want: [2]float32{15.5, 28.75}},
+		{name: "open-ended", in: "$30.00+", want: [2]float32{30, 30}},
+		{name: "open-ended no decimal", in: "$30+", want: [2]float32{30, 30}},
+		{name: "malformed", in: "n/a", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseHourlyRange(tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseHourlyRange(%q) = %v, nil; want error", tt.in, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseHourlyRange(%q) unexpected error: %v", tt.in, err)
+			}
+			if got != tt.want {
+				t.Errorf("parseHourlyRange(%q) = %v; want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePostedOn(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name: "long month name",
+			in:   "July 25, 2026 14:30 UTC",
+			want: time.Date(2026, time.July, 25, 14, 30, 0, 0, time.UTC),
+		},
+		{
+			name: "short month name",
+			in:   "Jul 25, 2026 14:30 UTC",
+			want: time.Date(2026, time.July, 25, 14, 30, 0, 0, time.UTC),
+		},
+		{
+			name: "numeric date",
+			in:   "2026-07-25 14:30:00 UTC",
+			want: time.Date(2026, time.July, 25, 14, 30, 0, 0, time.UTC),
+		},
+		{
+			name: "rfc1123",
+			in:   "Sat, 25 Jul 2026 14:30:00 UTC",
+			want: time.Date(2026, time.July, 25, 14, 30, 0, 0, time.UTC),
+		},
+		{name: "malformed", in: "not a date", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePostedOn(tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parsePostedOn(%q) = %v, nil; want error", tt.in, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parsePostedOn(%q) unexpected error: %v", tt.in, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("parsePostedOn(%q) = %v; want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
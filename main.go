@@ -1,219 +1,114 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
 	"flag"
-	"fmt"
-	"io/ioutil"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
 	"path/filepath"
-	"regexp"
-	"sort"
-	"strconv"
-	"strings"
+	"syscall"
 	"time"
 
-	"github.com/mmcdole/gofeed"
+	"github.com/zakkor/upfeed/config"
+	"github.com/zakkor/upfeed/feeds"
+	"github.com/zakkor/upfeed/server"
+	"github.com/zakkor/upfeed/storage"
 )
 
-type Job struct {
-	Title    string    `json:"title"`
-	PostedOn time.Time `json:"posted_on"`
-	Category string    `json:"category"`
-	Skills   []string  `json:"skills"`
-	Country  string    `json:"country"`
-
-	// If false, job is fixed price.
-	IsHourly    bool       `json:"is_hourly"`
-	HourlyRange [2]float32 `json:"hourly_range"`
-	// Only if IsHourly == false
-	Budget int `json:"budget"`
+func main() {
+	if len(os.Args) > 1 && os.Args[1] == "server" {
+		runServer(os.Args[2:])
+		return
+	}
+	runPoller(os.Args[1:])
 }
 
-func main() {
+func runPoller(args []string) {
+	fs := flag.NewFlagSet("upfeed", flag.ExitOnError)
 	var (
-		feedURL = flag.String("feed", "", "")
-		saveDir = flag.String("saveDir", "", "")
+		feedURL    = fs.String("feed", "", "single feed URL to poll; ignored if -config defines feeds")
+		saveDir    = fs.String("saveDir", "", "")
+		configPath = fs.String("config", "", "path to upfeed.yaml")
+		jsonStore  = fs.Bool("jsonStore", false, "use the JSON file store instead of SQLite (no CGO required)")
 	)
-	flag.Parse()
+	fs.Parse(args)
 
-	if *feedURL == "" {
-		panic("please specify -feed")
-	}
 	if *saveDir == "" {
 		panic("please specify -saveDir")
 	}
+	if *configPath == "" && *feedURL == "" {
+		panic("please specify -config or -feed")
+	}
 
-	var (
-		jobs           = LoadJobs(*saveDir, "filtered")
-		jobsUnfiltered = LoadJobs(*saveDir, "unfiltered")
-		feedParser     = gofeed.NewParser()
-	)
-
-	var recentJob time.Time
-	for {
-		feed, err := feedParser.ParseURL(*feedURL)
+	var cfg config.Config
+	if *configPath != "" {
+		var err error
+		cfg, err = config.Load(*configPath)
 		if err != nil {
 			log.Fatalln(err)
 		}
-
-		for _, item := range feed.Items {
-			job := ParseJob(item)
-			jobsUnfiltered[job.PostedOn] = job
-			SaveJobs(*saveDir, "unfiltered", jobsUnfiltered)
-
-			if job.PostedOn.After(recentJob) {
-				recentJob = job.PostedOn
-
-				if junk, reason := job.Junk(); !junk {
-					// New legit job was posted, save and send notification
-					jobs[job.PostedOn] = job
-					SaveJobs(*saveDir, "filtered", jobs)
-
-					err = Notify(job.Title, job.Format(), "assets/information.png")
-					if err != nil {
-						log.Fatalln(err)
-					}
-				} else {
-					// Job is junk
-					err = Notify("Job filtered out", reason, "assets/information.png")
-					if err != nil {
-						log.Fatalln(err)
-					}
-				}
-			}
-		}
-
-		time.Sleep(30 * time.Second)
 	}
-}
-
-func ParseJob(item *gofeed.Item) Job {
-	re := regexp.MustCompile(`<b>([a-zA-Z ]+)<\/b>:(.[^<]+)<`)
-
-	var job Job
-	// Hourly by default
-	job.IsHourly = true
-
-	job.Title = strings.TrimRight(item.Title, " - Upwork")
-
-	matches := re.FindAllStringSubmatch(item.Content, -1)
-	for _, match := range matches {
-		key := strings.TrimSpace(match[1])
-		val := strings.TrimSpace(match[2])
-
-		switch key {
-		case "Posted On":
-			layout := "January 2, 2006 15:04 MST"
-			t, err := time.Parse(layout, val)
-			if err != nil {
-				log.Fatal(err)
-			}
-			job.PostedOn = t
-		case "Category":
-			job.Category = val
-		case "Skills":
-			skills := strings.Split(val, ", ")
-			for i := range skills {
-				skills[i] = strings.TrimSpace(skills[i])
-			}
-			job.Skills = skills
-		case "Country":
-			job.Country = val
-		case "Budget":
-			val = strings.ReplaceAll(val, "$", "")
-			val = strings.ReplaceAll(val, ",", "")
-			budget64, err := strconv.ParseInt(val, 10, 64)
-			if err != nil {
-				log.Fatalln(err)
-			}
-			job.Budget = int(budget64)
-			job.IsHourly = false
-		case "Hourly Range":
-			val = strings.ReplaceAll(val, "$", "")
-			split := strings.Split(val, "-")
-			job.HourlyRange = [2]float32{0.0, 0.0}
-			lower64, err := strconv.ParseFloat(split[0], 32)
-			if err != nil {
-				log.Fatalln(err)
-			}
-			job.HourlyRange[0] = float32(lower64)
-			if len(split) > 1 {
-				upper64, err := strconv.ParseFloat(split[1], 32)
-				if err != nil {
-					log.Fatalln(err)
-				}
-				job.HourlyRange[1] = float32(upper64)
-			}
-		}
+	if *feedURL != "" && len(cfg.Feeds) == 0 {
+		cfg.Feeds = append(cfg.Feeds, config.FeedConfig{ID: "default", URL: *feedURL, PollInterval: 30 * time.Second})
 	}
 
-	return job
-}
-
-func (j *Job) Format() string {
-	format := fmt.Sprintf("Country: %s\n", j.Country)
-
-	if j.IsHourly {
-		format += fmt.Sprintf("Type: Hourly\nHourly Range: $%v-$%v\n", j.HourlyRange[0], j.HourlyRange[1])
-	} else {
-		format += fmt.Sprintf("Type: Fixed price\nBudget: $%v\n", j.Budget)
+	store, err := openStore(*saveDir, *jsonStore)
+	if err != nil {
+		log.Fatalln(err)
 	}
+	defer store.Close()
 
-	return format
-}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-// Junk returns true and the reason for filtering, if the job should be filtered out.
-func (j *Job) Junk() (bool, string) {
-	if j.Country == "India" || j.Country == "Nigeria" {
-		return true, fmt.Sprintf("Country is %s", j.Country)
+	manager := feeds.NewManager(store)
+	if err := manager.Run(ctx, cfg); err != nil {
+		log.Fatalln(err)
 	}
-
-	return false, ""
 }
 
-func SaveJobs(dir, category string, jobs map[time.Time]Job) {
-	filename := fmt.Sprintf("upfeed_%s_%s.json", time.Now().Format("02-01-2006"), category)
+// runServer runs `upfeed server`, exposing the job history saved by
+// runPoller over HTTP.
+func runServer(args []string) {
+	fs := flag.NewFlagSet("upfeed server", flag.ExitOnError)
+	var (
+		saveDir   = fs.String("saveDir", "", "")
+		addr      = fs.String("addr", ":8080", "address to listen on")
+		jsonStore = fs.Bool("jsonStore", false, "use the JSON file store instead of SQLite (no CGO required)")
+	)
+	fs.Parse(args)
 
-	var js []Job
-	for _, job := range jobs {
-		js = append(js, job)
+	if *saveDir == "" {
+		panic("please specify -saveDir")
 	}
 
-	sort.Slice(js, func(i, j int) bool {
-		return js[i].PostedOn.After(js[j].PostedOn)
-	})
-
-	data, err := json.Marshal(js)
+	store, err := openStore(*saveDir, *jsonStore)
 	if err != nil {
 		log.Fatalln(err)
 	}
+	defer store.Close()
 
-	path := filepath.Join(dir, filename)
-	err = ioutil.WriteFile(path, data, 0755)
-	if err != nil {
-		log.Fatalln(err)
-	}
+	srv := server.New(store)
+	log.Printf("upfeed server listening on %s", *addr)
+	log.Fatalln(http.ListenAndServe(*addr, srv.Routes()))
 }
 
-func LoadJobs(dir, category string) map[time.Time]Job {
-	filename := fmt.Sprintf("upfeed_%s_%s.json", time.Now().Format("02-01-2006"), category)
-	path := filepath.Join(dir, filename)
-	data, err := ioutil.ReadFile(path)
-	if err != nil {
-		return make(map[time.Time]Job)
+// openStore opens the configured persistence backend, migrating any
+// pre-existing JSON dumps in saveDir into it on first run.
+func openStore(saveDir string, useJSON bool) (storage.Store, error) {
+	if useJSON {
+		return storage.NewJSONStore(saveDir)
 	}
 
-	var js []Job
-	err = json.Unmarshal(data, &js)
+	store, err := storage.NewSQLiteStore(filepath.Join(saveDir, "upfeed.db"))
 	if err != nil {
-		log.Fatalln(err)
+		return nil, err
 	}
-
-	var jobs = make(map[time.Time]Job)
-	for _, job := range js {
-		jobs[job.PostedOn] = job
+	if err := storage.MigrateFromJSON(store, saveDir); err != nil {
+		store.Close()
+		return nil, err
 	}
-
-	return jobs
+	return store, nil
 }
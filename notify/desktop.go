@@ -0,0 +1,11 @@
+package notify
+
+import "github.com/gen2brain/beeep"
+
+// DesktopSink shows an OS-level popup notification. This is upfeed's
+// original (and still default) notification behavior.
+type DesktopSink struct{}
+
+func (DesktopSink) Send(n Notification) error {
+	return beeep.Notify(n.Title, n.Body, n.Icon)
+}
@@ -0,0 +1,24 @@
+package notify
+
+import "strings"
+
+// joinErrors combines multiple sink failures into one error, so a
+// caller that only logs err.Error() still sees every sink that failed.
+func joinErrors(errs []error) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	if len(errs) == 1 {
+		return errs[0]
+	}
+
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+	return multiError(strings.Join(msgs, "; "))
+}
+
+type multiError string
+
+func (m multiError) Error() string { return string(m) }
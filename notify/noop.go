@@ -0,0 +1,7 @@
+package notify
+
+// NoopSink discards every notification. Useful for feeds that should
+// only populate the job history without alerting anyone.
+type NoopSink struct{}
+
+func (NoopSink) Send(Notification) error { return nil }
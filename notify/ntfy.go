@@ -0,0 +1,39 @@
+package notify
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// NtfySink publishes to a topic on ntfy.sh (or a self-hosted ntfy
+// server), per https://docs.ntfy.sh/publish/.
+type NtfySink struct {
+	// ServerURL defaults to https://ntfy.sh if empty.
+	ServerURL string
+	Topic     string
+}
+
+func (s NtfySink) Send(n Notification) error {
+	server := s.ServerURL
+	if server == "" {
+		server = "https://ntfy.sh"
+	}
+
+	req, err := http.NewRequest(http.MethodPost, strings.TrimRight(server, "/")+"/"+s.Topic, strings.NewReader(n.Body))
+	if err != nil {
+		return fmt.Errorf("build ntfy request: %w", err)
+	}
+	req.Header.Set("Title", n.Title)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("post ntfy: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("ntfy topic %q returned %s", s.Topic, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+// Package notify defines pluggable notification backends for upfeed.
+package notify
+
+import "github.com/zakkor/upfeed/job"
+
+// Notification is a single user-facing alert. Job is set when the
+// notification is about a specific job (nil for things like "filtered
+// out" reasons that only have title/body).
+type Notification struct {
+	Title string
+	Body  string
+	Icon  string
+	Job   *job.Job
+}
+
+// Sink delivers Notifications somewhere: a desktop popup, a chat
+// webhook, email, etc.
+type Sink interface {
+	Send(n Notification) error
+}
+
+// Multi fans a Notification out to every Sink, collecting any errors
+// instead of stopping at the first failure.
+type Multi []Sink
+
+func (m Multi) Send(n Notification) error {
+	var errs []error
+	for _, sink := range m {
+		if err := sink.Send(n); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return joinErrors(errs)
+}
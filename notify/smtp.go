@@ -0,0 +1,52 @@
+package notify
+
+import (
+	"fmt"
+	"net/smtp"
+	"strings"
+)
+
+// SMTPSink emails a Notification through a standard SMTP relay.
+type SMTPSink struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	To       string
+}
+
+func (s SMTPSink) Send(n Notification) error {
+	addr := fmt.Sprintf("%s:%d", s.Host, s.Port)
+	auth := smtp.PlainAuth("", s.Username, s.Password, s.Host)
+
+	msg := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s\r\n",
+		s.From, s.To, sanitizeHeader(n.Title), dotStuff(n.Body))
+
+	if err := smtp.SendMail(addr, auth, s.From, []string{s.To}, []byte(msg)); err != nil {
+		return fmt.Errorf("send mail: %w", err)
+	}
+	return nil
+}
+
+// sanitizeHeader strips CR/LF from a value bound for a raw message header,
+// since n.Title comes straight from external feed content and an
+// unsanitized "\r\n" would let a feed item inject arbitrary extra headers.
+func sanitizeHeader(s string) string {
+	s = strings.ReplaceAll(s, "\r", " ")
+	s = strings.ReplaceAll(s, "\n", " ")
+	return s
+}
+
+// dotStuff escapes any line in the message body that starts with "." by
+// doubling it, so feed content can't prematurely terminate the SMTP DATA
+// command with a lone "." line.
+func dotStuff(body string) string {
+	lines := strings.Split(body, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, ".") {
+			lines[i] = "." + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
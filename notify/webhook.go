@@ -0,0 +1,58 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// WebhookSink POSTs a JSON body of the Job (or, for non-job
+// notifications, just the title/body) to an arbitrary URL.
+type WebhookSink struct {
+	URL string
+}
+
+func (s WebhookSink) Send(n Notification) error {
+	var body interface{} = n.Job
+	if n.Job == nil {
+		body = map[string]string{"title": n.Title, "body": n.Body}
+	}
+	return postJSON(s.URL, body)
+}
+
+// DiscordSink posts to a Discord incoming webhook URL.
+type DiscordSink struct {
+	URL string
+}
+
+func (s DiscordSink) Send(n Notification) error {
+	return postJSON(s.URL, map[string]string{"content": fmt.Sprintf("**%s**\n%s", n.Title, n.Body)})
+}
+
+// SlackSink posts to a Slack incoming webhook URL.
+type SlackSink struct {
+	URL string
+}
+
+func (s SlackSink) Send(n Notification) error {
+	return postJSON(s.URL, map[string]string{"text": fmt.Sprintf("*%s*\n%s", n.Title, n.Body)})
+}
+
+func postJSON(url string, body interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("marshal webhook body: %w", err)
+	}
+
+	resp, err := http.Post(url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s returned %s", url, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/feeds"
+	"github.com/zakkor/upfeed/job"
+)
+
+// wantsFeed reports whether the client asked for RSS/Atom instead of JSON.
+func wantsFeed(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), "rss") || strings.Contains(r.Header.Get("Accept"), "atom")
+}
+
+// writeFeed renders jobs as RSS or Atom, depending on which the client's
+// Accept header asked for, so a filtered job history can be subscribed
+// to from any feed reader.
+func writeFeed(w http.ResponseWriter, r *http.Request, jobs []job.Job) {
+	feed := &feeds.Feed{
+		Title:       "upfeed: filtered jobs",
+		Description: "Jobs matching your upfeed filter rules",
+		Link:        &feeds.Link{Href: r.URL.String()},
+	}
+
+	for _, j := range jobs {
+		j := j
+		feed.Items = append(feed.Items, &feeds.Item{
+			Id:          j.GUID,
+			Title:       j.Title,
+			Link:        &feeds.Link{Href: j.URL},
+			Description: j.Format(),
+			Created:     j.PostedOn,
+		})
+	}
+
+	if strings.Contains(r.Header.Get("Accept"), "atom") {
+		w.Header().Set("Content-Type", "application/atom+xml")
+		feed.WriteAtom(w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/rss+xml")
+	feed.WriteRss(w)
+}
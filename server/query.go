@@ -0,0 +1,121 @@
+package server
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zakkor/upfeed/job"
+)
+
+// jobQuery is the parsed form of a GET /jobs request.
+type jobQuery struct {
+	oldest, newest time.Time
+	country        string
+	skills         []string
+	minBudget      float64
+	hourlyOnly     bool
+	titleRegex     *regexp.Regexp
+}
+
+func parseJobQuery(r *http.Request) (jobQuery, error) {
+	q := r.URL.Query()
+	var jq jobQuery
+
+	if v := q.Get("oldest"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return jq, err
+		}
+		jq.oldest = t
+	}
+	if v := q.Get("newest"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return jq, err
+		}
+		jq.newest = t
+	}
+
+	jq.country = q.Get("country")
+	jq.skills = q["skill"]
+
+	if v := q.Get("min-budget"); v != "" {
+		min, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return jq, err
+		}
+		jq.minBudget = min
+	}
+
+	_, jq.hourlyOnly = q["hourly"]
+
+	if v := q.Get("title-regex"); v != "" {
+		pattern := v
+		if _, ignoreCase := q["ignore-case"]; ignoreCase {
+			pattern = "(?i)" + pattern
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return jq, err
+		}
+		jq.titleRegex = re
+	}
+
+	return jq, nil
+}
+
+// match reports whether j satisfies every predicate set on jq.
+func (jq jobQuery) match(j job.Job) bool {
+	if !jq.oldest.IsZero() && j.PostedOn.Before(jq.oldest) {
+		return false
+	}
+	if !jq.newest.IsZero() && j.PostedOn.After(jq.newest) {
+		return false
+	}
+	if jq.country != "" && !strings.EqualFold(jq.country, j.Country) {
+		return false
+	}
+	for _, skill := range jq.skills {
+		if !hasSkill(j.Skills, skill) {
+			return false
+		}
+	}
+	if jq.hourlyOnly && !j.IsHourly {
+		return false
+	}
+	if jq.minBudget > 0 {
+		if j.IsHourly {
+			if float64(j.HourlyRange[1]) < jq.minBudget {
+				return false
+			}
+		} else if float64(j.Budget) < jq.minBudget {
+			return false
+		}
+	}
+	if jq.titleRegex != nil && !jq.titleRegex.MatchString(j.Title) {
+		return false
+	}
+	return true
+}
+
+func hasSkill(skills []string, want string) bool {
+	for _, s := range skills {
+		if strings.EqualFold(s, want) {
+			return true
+		}
+	}
+	return false
+}
+
+func filterJobs(jobs []job.Job, jq jobQuery) []job.Job {
+	var out []job.Job
+	for _, j := range jobs {
+		if jq.match(j) {
+			out = append(out, j)
+		}
+	}
+	return out
+}
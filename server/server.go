@@ -0,0 +1,100 @@
+// Package server exposes the stored job history over HTTP: a search API
+// over /jobs (JSON or, on request, RSS/Atom) and aggregate counts at
+// /stats.
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/zakkor/upfeed/job"
+	"github.com/zakkor/upfeed/storage"
+)
+
+// Server serves the job history held in Store.
+type Server struct {
+	Store storage.Store
+}
+
+// New returns a Server reading from store.
+func New(store storage.Store) *Server {
+	return &Server{Store: store}
+}
+
+// Routes returns the server's HTTP handler.
+func (s *Server) Routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/jobs", s.handleJobs)
+	mux.HandleFunc("/stats", s.handleStats)
+	return mux
+}
+
+func (s *Server) handleJobs(w http.ResponseWriter, r *http.Request) {
+	jq, err := parseJobQuery(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	jobs, err := s.Store.AllJobs(true)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	jobs = filterJobs(jobs, jq)
+
+	if wantsFeed(r) {
+		writeFeed(w, r, jobs)
+		return
+	}
+
+	writeJSON(w, jobs)
+}
+
+func (s *Server) handleStats(w http.ResponseWriter, r *http.Request) {
+	jq, err := parseJobQuery(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	jobs, err := s.Store.AllJobs(true)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	jobs = filterJobs(jobs, jq)
+
+	writeJSON(w, computeStats(jobs))
+}
+
+type stats struct {
+	Total      int            `json:"total"`
+	ByCountry  map[string]int `json:"by_country"`
+	ByCategory map[string]int `json:"by_category"`
+	BySkill    map[string]int `json:"by_skill"`
+}
+
+func computeStats(jobs []job.Job) stats {
+	st := stats{
+		ByCountry:  make(map[string]int),
+		ByCategory: make(map[string]int),
+		BySkill:    make(map[string]int),
+	}
+
+	for _, j := range jobs {
+		st.Total++
+		st.ByCountry[j.Country]++
+		st.ByCategory[j.Category]++
+		for _, skill := range j.Skills {
+			st.BySkill[skill]++
+		}
+	}
+
+	return st
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,266 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/zakkor/upfeed/job"
+)
+
+// JSONStore is a Store backed by one JSON file per feed+category. It
+// requires no CGO, at the cost of rewriting the whole file on every
+// write; it exists as a dependency-free fallback to SQLiteStore.
+type JSONStore struct {
+	dir string
+
+	mu              sync.Mutex
+	seen            map[string]bool
+	seenQuarantined map[string]bool
+	feedIDs         map[string]bool
+}
+
+// NewJSONStore opens (or creates) a JSON-backed store rooted at dir.
+func NewJSONStore(dir string) (*JSONStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	s := &JSONStore{
+		dir:             dir,
+		seen:            make(map[string]bool),
+		seenQuarantined: make(map[string]bool),
+		feedIDs:         make(map[string]bool),
+	}
+
+	for _, feedID := range feedIDsInDir(dir) {
+		s.feedIDs[feedID] = true
+		for _, filtered := range []bool{true, false} {
+			js, err := s.readFile(feedID, filtered)
+			if err != nil {
+				return nil, err
+			}
+			for _, j := range js {
+				s.seen[Key(feedID, j.GUID)] = true
+			}
+		}
+
+		entries, err := s.readQuarantine(feedID)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			s.seenQuarantined[Key(feedID, e.GUID)] = true
+		}
+	}
+
+	return s, nil
+}
+
+func (s *JSONStore) Save(feedID string, j job.Job, filtered bool, reason string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.feedIDs[feedID] = true
+
+	js, err := s.readFile(feedID, filtered)
+	if err != nil {
+		return err
+	}
+
+	js = append(js, j)
+	sort.Slice(js, func(i, k int) bool { return js[i].PostedOn.After(js[k].PostedOn) })
+
+	if err := s.writeFile(feedID, filtered, js); err != nil {
+		return err
+	}
+
+	s.seen[Key(feedID, j.GUID)] = true
+	return nil
+}
+
+func (s *JSONStore) Jobs(feedID string, filteredOnly bool) ([]job.Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.jobsForFeed(feedID, filteredOnly)
+}
+
+func (s *JSONStore) AllJobs(filteredOnly bool) ([]job.Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var all []job.Job
+	for feedID := range s.feedIDs {
+		js, err := s.jobsForFeed(feedID, filteredOnly)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, js...)
+	}
+
+	sort.Slice(all, func(i, k int) bool { return all[i].PostedOn.After(all[k].PostedOn) })
+	return all, nil
+}
+
+// jobsForFeed returns the jobs stored for feedID. If filteredOnly is true,
+// only jobs that passed the filter rules (the "filtered" file) are
+// returned; otherwise every stored job is returned, regardless of which
+// of the two per-category files it landed in.
+func (s *JSONStore) jobsForFeed(feedID string, filteredOnly bool) ([]job.Job, error) {
+	filtered, err := s.readFile(feedID, true)
+	if err != nil {
+		return nil, err
+	}
+	if filteredOnly {
+		return filtered, nil
+	}
+
+	unfiltered, err := s.readFile(feedID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	all := append(filtered, unfiltered...)
+	sort.Slice(all, func(i, k int) bool { return all[i].PostedOn.After(all[k].PostedOn) })
+	return all, nil
+}
+
+func (s *JSONStore) Has(feedID, guid string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.seen[Key(feedID, guid)], nil
+}
+
+// quarantinedJob is the on-disk shape of a failed-to-parse feed item.
+type quarantinedJob struct {
+	GUID  string `json:"guid"`
+	Raw   string `json:"raw"`
+	Error string `json:"error"`
+}
+
+func (s *JSONStore) Quarantine(feedID, guid, raw string, parseErr error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := Key(feedID, guid)
+	if s.seenQuarantined[key] {
+		return nil
+	}
+
+	entries, err := s.readQuarantine(feedID)
+	if err != nil {
+		return err
+	}
+	entries = append(entries, quarantinedJob{GUID: guid, Raw: raw, Error: parseErr.Error()})
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(s.quarantinePath(feedID), data, 0755); err != nil {
+		return err
+	}
+
+	s.seenQuarantined[key] = true
+	return nil
+}
+
+func (s *JSONStore) HasQuarantined(feedID, guid string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.seenQuarantined[Key(feedID, guid)], nil
+}
+
+func (s *JSONStore) quarantinePath(feedID string) string {
+	return filepath.Join(s.dir, fmt.Sprintf("upfeed_%s_quarantine.json", feedID))
+}
+
+func (s *JSONStore) readQuarantine(feedID string) ([]quarantinedJob, error) {
+	data, err := ioutil.ReadFile(s.quarantinePath(feedID))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []quarantinedJob
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (s *JSONStore) Close() error { return nil }
+
+func (s *JSONStore) path(feedID string, filtered bool) string {
+	category := "unfiltered"
+	if filtered {
+		category = "filtered"
+	}
+	return filepath.Join(s.dir, fmt.Sprintf("upfeed_%s_%s.json", feedID, category))
+}
+
+func (s *JSONStore) readFile(feedID string, filtered bool) ([]job.Job, error) {
+	data, err := ioutil.ReadFile(s.path(feedID, filtered))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var js []job.Job
+	if err := json.Unmarshal(data, &js); err != nil {
+		return nil, err
+	}
+	return js, nil
+}
+
+func (s *JSONStore) writeFile(feedID string, filtered bool, js []job.Job) error {
+	data, err := json.Marshal(js)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(s.path(feedID, filtered), data, 0755)
+}
+
+// feedIDsInDir scans dir for existing "upfeed_<id>_(filtered|unfiltered).json"
+// files and returns the distinct feed IDs found, so NewJSONStore can seed
+// its dedup set from them.
+func feedIDsInDir(dir string) []string {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var ids []string
+	for _, e := range entries {
+		name := e.Name()
+		if !strings.HasPrefix(name, "upfeed_") || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		name = strings.TrimPrefix(name, "upfeed_")
+		name = strings.TrimSuffix(name, ".json")
+		switch {
+		case strings.HasSuffix(name, "_unfiltered"):
+			name = strings.TrimSuffix(name, "_unfiltered")
+		case strings.HasSuffix(name, "_filtered"):
+			name = strings.TrimSuffix(name, "_filtered")
+		default:
+			continue
+		}
+
+		if name != "" && !seen[name] {
+			seen[name] = true
+			ids = append(ids, name)
+		}
+	}
+	return ids
+}
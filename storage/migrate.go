@@ -0,0 +1,36 @@
+package storage
+
+// MigrateFromJSON imports every job found in the legacy per-feed JSON
+// dumps under jsonDir into dst, skipping any job already present. It is
+// safe to call on every startup: once migrated, Has() makes subsequent
+// runs a no-op.
+func MigrateFromJSON(dst Store, jsonDir string) error {
+	src, err := NewJSONStore(jsonDir)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	for _, feedID := range feedIDsInDir(jsonDir) {
+		for _, filtered := range []bool{true, false} {
+			jobs, err := src.Jobs(feedID, filtered)
+			if err != nil {
+				return err
+			}
+			for _, j := range jobs {
+				has, err := dst.Has(feedID, j.GUID)
+				if err != nil {
+					return err
+				}
+				if has {
+					continue
+				}
+				if err := dst.Save(feedID, j, filtered, "migrated from json"); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,238 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/zakkor/upfeed/job"
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS feeds (
+	id TEXT PRIMARY KEY
+);
+
+CREATE TABLE IF NOT EXISTS jobs (
+	key              TEXT PRIMARY KEY, -- Key(feed_id, guid)
+	feed_id          TEXT NOT NULL REFERENCES feeds(id),
+	guid             TEXT NOT NULL,
+	url              TEXT NOT NULL,
+	title            TEXT NOT NULL,
+	description      TEXT NOT NULL,
+	posted_on        DATETIME NOT NULL,
+	category         TEXT NOT NULL,
+	country          TEXT NOT NULL,
+	is_hourly        BOOLEAN NOT NULL,
+	hourly_low       REAL NOT NULL,
+	hourly_high      REAL NOT NULL,
+	budget           INTEGER NOT NULL,
+	payment_verified BOOLEAN NOT NULL,
+	spent            TEXT NOT NULL,
+	hire_rate        TEXT NOT NULL,
+	client_location  TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS skills (
+	job_key TEXT NOT NULL REFERENCES jobs(key),
+	skill   TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS filter_decisions (
+	job_key  TEXT PRIMARY KEY REFERENCES jobs(key),
+	filtered BOOLEAN NOT NULL,
+	reason   TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS quarantine (
+	feed_id        TEXT NOT NULL REFERENCES feeds(id),
+	guid           TEXT NOT NULL,
+	raw            TEXT NOT NULL,
+	error          TEXT NOT NULL,
+	quarantined_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_jobs_feed_id ON jobs(feed_id);
+CREATE INDEX IF NOT EXISTS idx_skills_job_key ON skills(job_key);
+-- Quarantine's INSERT OR IGNORE relies on this index to record each
+-- (feed_id, guid) only once.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_quarantine_feed_guid ON quarantine(feed_id, guid);
+`
+
+// SQLiteStore is a Store backed by SQLite via the pure-Go
+// modernc.org/sqlite driver, so no CGO toolchain is required.
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteStore opens (creating if necessary) a SQLite database at path
+// and ensures its schema exists.
+//
+// SQLite allows only one writer at a time; with feeds.Manager running one
+// goroutine per feed against this same *sql.DB, concurrent Save/Quarantine
+// calls would otherwise fail immediately with SQLITE_BUSY. busy_timeout
+// makes a blocked writer retry instead of erroring, and capping the pool
+// to a single connection serializes writes on the Go side too.
+func NewSQLiteStore(path string) (*SQLiteStore, error) {
+	db, err := sql.Open("sqlite", path+"?_pragma=busy_timeout(5000)")
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite db: %w", err)
+	}
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("create schema: %w", err)
+	}
+
+	return &SQLiteStore{db: db}, nil
+}
+
+func (s *SQLiteStore) Save(feedID string, j job.Job, filtered bool, reason string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`INSERT OR IGNORE INTO feeds (id) VALUES (?)`, feedID); err != nil {
+		return err
+	}
+
+	key := Key(feedID, j.GUID)
+	_, err = tx.Exec(`
+		INSERT OR REPLACE INTO jobs
+			(key, feed_id, guid, url, title, description, posted_on, category, country,
+			 is_hourly, hourly_low, hourly_high, budget, payment_verified, spent, hire_rate, client_location)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		key, feedID, j.GUID, j.URL, j.Title, j.Description, j.PostedOn, j.Category, j.Country,
+		j.IsHourly, j.HourlyRange[0], j.HourlyRange[1], j.Budget,
+		j.Client.PaymentVerified, j.Client.Spent, j.Client.HireRate, j.Client.Location)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM skills WHERE job_key = ?`, key); err != nil {
+		return err
+	}
+	for _, skill := range j.Skills {
+		if _, err := tx.Exec(`INSERT INTO skills (job_key, skill) VALUES (?, ?)`, key, skill); err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec(`INSERT OR REPLACE INTO filter_decisions (job_key, filtered, reason) VALUES (?, ?, ?)`,
+		key, filtered, reason)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *SQLiteStore) Jobs(feedID string, filteredOnly bool) ([]job.Job, error) {
+	return s.queryJobs(`WHERE j.feed_id = ?`, []interface{}{feedID}, filteredOnly)
+}
+
+func (s *SQLiteStore) AllJobs(filteredOnly bool) ([]job.Job, error) {
+	return s.queryJobs(``, nil, filteredOnly)
+}
+
+func (s *SQLiteStore) queryJobs(where string, args []interface{}, filteredOnly bool) ([]job.Job, error) {
+	query := `
+		SELECT j.key, j.guid, j.url, j.title, j.description, j.posted_on, j.category, j.country,
+		       j.is_hourly, j.hourly_low, j.hourly_high, j.budget,
+		       j.payment_verified, j.spent, j.hire_rate, j.client_location
+		FROM jobs j
+		JOIN filter_decisions fd ON fd.job_key = j.key
+		` + where
+	if filteredOnly {
+		if where == "" {
+			query += ` WHERE fd.filtered = 1`
+		} else {
+			query += ` AND fd.filtered = 1`
+		}
+	}
+	query += ` ORDER BY j.posted_on DESC`
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var jobs []job.Job
+	var keys []string
+	for rows.Next() {
+		var j job.Job
+		var key string
+		if err := rows.Scan(&key, &j.GUID, &j.URL, &j.Title, &j.Description, &j.PostedOn, &j.Category, &j.Country,
+			&j.IsHourly, &j.HourlyRange[0], &j.HourlyRange[1], &j.Budget,
+			&j.Client.PaymentVerified, &j.Client.Spent, &j.Client.HireRate, &j.Client.Location); err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, j)
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i, key := range keys {
+		skills, err := s.skillsFor(key)
+		if err != nil {
+			return nil, err
+		}
+		jobs[i].Skills = skills
+	}
+
+	return jobs, nil
+}
+
+func (s *SQLiteStore) skillsFor(jobKey string) ([]string, error) {
+	rows, err := s.db.Query(`SELECT skill FROM skills WHERE job_key = ?`, jobKey)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var skills []string
+	for rows.Next() {
+		var skill string
+		if err := rows.Scan(&skill); err != nil {
+			return nil, err
+		}
+		skills = append(skills, skill)
+	}
+	return skills, rows.Err()
+}
+
+func (s *SQLiteStore) Has(feedID, guid string) (bool, error) {
+	var count int
+	err := s.db.QueryRow(`SELECT COUNT(*) FROM jobs WHERE key = ?`, Key(feedID, guid)).Scan(&count)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (s *SQLiteStore) Quarantine(feedID, guid, raw string, parseErr error) error {
+	if _, err := s.db.Exec(`INSERT OR IGNORE INTO feeds (id) VALUES (?)`, feedID); err != nil {
+		return err
+	}
+	_, err := s.db.Exec(`INSERT OR IGNORE INTO quarantine (feed_id, guid, raw, error) VALUES (?, ?, ?, ?)`,
+		feedID, guid, raw, parseErr.Error())
+	return err
+}
+
+func (s *SQLiteStore) HasQuarantined(feedID, guid string) (bool, error) {
+	var count int
+	err := s.db.QueryRow(`SELECT COUNT(*) FROM quarantine WHERE feed_id = ? AND guid = ?`, feedID, guid).Scan(&count)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (s *SQLiteStore) Close() error { return s.db.Close() }
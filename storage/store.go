@@ -0,0 +1,45 @@
+// Package storage persists jobs, their originating feeds, and the filter
+// decisions made about them, behind a backend-agnostic Store interface.
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/zakkor/upfeed/job"
+)
+
+// Store persists jobs per feed, deduplicated by a stable hash of
+// (feed ID, GUID) rather than PostedOn, since two jobs can share a
+// timestamp.
+type Store interface {
+	// Save upserts j for feedID. Filtered records whether the job passed
+	// the filter rules; reason says why it didn't (the poller passes
+	// filter.Set.Junk's "filtered by rule: <name>" text).
+	Save(feedID string, j job.Job, filtered bool, reason string) error
+	// Jobs returns every stored job for feedID, newest first. If
+	// filteredOnly is true, only jobs that passed the filter rules are
+	// returned.
+	Jobs(feedID string, filteredOnly bool) ([]job.Job, error)
+	// AllJobs returns every stored job across all feeds, newest first.
+	AllJobs(filteredOnly bool) ([]job.Job, error)
+	// Has reports whether a job with this feedID+GUID has already been
+	// stored, regardless of filter outcome.
+	Has(feedID, guid string) (bool, error)
+	// Quarantine records a feed item that failed to parse, along with why,
+	// so it can be inspected later instead of crashing the poller. It is
+	// deduplicated by feedID+GUID like Save, so a permanently-malformed
+	// item quarantines once rather than on every poll tick.
+	Quarantine(feedID, guid, raw string, parseErr error) error
+	// HasQuarantined reports whether a feed item with this feedID+GUID has
+	// already been quarantined, so the poller can skip re-parsing it.
+	HasQuarantined(feedID, guid string) (bool, error)
+	Close() error
+}
+
+// Key returns the stable dedup key for a job: a hash of its feed ID and
+// GUID.
+func Key(feedID, guid string) string {
+	sum := sha256.Sum256([]byte(feedID + "\x00" + guid))
+	return hex.EncodeToString(sum[:])
+}